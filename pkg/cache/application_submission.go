@@ -0,0 +1,70 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/common/resources"
+)
+
+// AddNewApplication is the intended entry point for admitting a new
+// application into the partition: the application's real submission path,
+// from the RM shim through the scheduler, lives outside pkg/cache and is not
+// part of this tree, so this is not yet wired up as the sole way an
+// application reaches a queue. Callers that do invoke it get the full
+// pipeline: the queue is resolved by the partition's placement rule chain
+// rather than trusted straight off the request, and the submission is
+// rejected if placement fails, if the placed queue does not exist, if the
+// queue or any of its ancestors is already at its configured MaxApplications
+// ceiling, or if admitting it would push the submitting user or any of their
+// groups over a configured quota.
+func (pi *PartitionInfo) AddNewApplication(app *ApplicationInfo) error {
+	queuePath := app.QueueName
+	if pi.placementManager != nil {
+		placed, err := pi.placementManager.PlaceApplication(app)
+		if err != nil {
+			return err
+		}
+		queuePath = placed
+	}
+
+	queue := FindQueueByPath(pi.Root, queuePath)
+	if queue == nil {
+		return fmt.Errorf("application %s placed onto unknown queue %s", app.ApplicationID, queuePath)
+	}
+
+	if err := assertMaxApplicationsNotExceeded(queue); err != nil {
+		return err
+	}
+
+	zero := resources.NewResource()
+	if err := assertUserGroupLimitsNotExceeded(pi.userGroupTracker, app.User, app.Groups, queuePath, zero, true); err != nil {
+		return err
+	}
+
+	app.QueueName = queuePath
+	queue.applications[app.ApplicationID] = app
+	pi.applications[app.ApplicationID] = app
+	if pi.userGroupTracker != nil {
+		pi.userGroupTracker.recordUsage(app.User, app.Groups, queuePath, zero, true)
+	}
+
+	return nil
+}
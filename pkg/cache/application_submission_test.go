@@ -0,0 +1,59 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "testing"
+
+func TestAddNewApplicationRejectsUnknownQueue(t *testing.T) {
+	root := &QueueInfo{Name: "root", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{}}
+	pi := &PartitionInfo{Root: root, applications: map[string]*ApplicationInfo{}}
+
+	app := &ApplicationInfo{ApplicationID: "app-1", QueueName: "root.missing"}
+	if err := pi.AddNewApplication(app); err == nil {
+		t.Fatal("expected an error submitting to a queue that does not exist")
+	}
+}
+
+func TestAddNewApplicationRejectsAtMaxApplications(t *testing.T) {
+	leaf := &QueueInfo{Name: "root.default", MaxApplications: 1, children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{"existing": {}}}
+	root := &QueueInfo{Name: "root", children: map[string]*QueueInfo{"default": leaf}, applications: map[string]*ApplicationInfo{}}
+	pi := &PartitionInfo{Root: root, applications: map[string]*ApplicationInfo{}}
+
+	app := &ApplicationInfo{ApplicationID: "app-2", QueueName: "root.default"}
+	if err := pi.AddNewApplication(app); err == nil {
+		t.Fatal("expected an error submitting to a queue already at its MaxApplications limit")
+	}
+}
+
+func TestAddNewApplicationAdmitsUnderLimit(t *testing.T) {
+	leaf := &QueueInfo{Name: "root.default", MaxApplications: 2, children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{"existing": {}}}
+	root := &QueueInfo{Name: "root", children: map[string]*QueueInfo{"default": leaf}, applications: map[string]*ApplicationInfo{}}
+	pi := &PartitionInfo{Root: root, applications: map[string]*ApplicationInfo{}}
+
+	app := &ApplicationInfo{ApplicationID: "app-3", QueueName: "root.default"}
+	if err := pi.AddNewApplication(app); err != nil {
+		t.Fatalf("expected application to be admitted under the limit, got: %v", err)
+	}
+	if leaf.RunningApplications() != 2 {
+		t.Fatalf("expected 2 running applications in the queue, got %d", leaf.RunningApplications())
+	}
+	if _, ok := pi.applications[app.ApplicationID]; !ok {
+		t.Fatal("expected application to be tracked on the partition")
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+// GetPartition returns the partition registered under the given normalized
+// name, or nil if the cluster does not know about it. Exported for the
+// webservice layer, which lives outside this package.
+func (m *ClusterInfo) GetPartition(partitionName string) *PartitionInfo {
+	return m.partitions[partitionName]
+}
+
+// PlacementRuleNames returns the partition's active placement rule chain, in
+// evaluation order, for the placement rules DAO.
+func (pi *PartitionInfo) PlacementRuleNames() []string {
+	if pi.placementManager == nil {
+		return nil
+	}
+	return pi.placementManager.RuleNames()
+}
+
+// GetUserUsage returns the recorded resource and application usage for a user
+// in this partition, for the /usage/user/:name REST call.
+func (pi *PartitionInfo) GetUserUsage(name string) *UserResourceUsage {
+	if pi.userGroupTracker == nil {
+		return summarizeUsage(name, nil)
+	}
+	return pi.userGroupTracker.GetUserUsage(name)
+}
+
+// GetGroupUsage returns the recorded resource and application usage for a
+// group in this partition, for the /usage/group/:name REST call.
+func (pi *PartitionInfo) GetGroupUsage(name string) *UserResourceUsage {
+	if pi.userGroupTracker == nil {
+		return summarizeUsage(name, nil)
+	}
+	return pi.userGroupTracker.GetGroupUsage(name)
+}
+
+// AggregateUsage returns every user's and group's recorded usage in this
+// partition, for the aggregate partition usage view.
+func (pi *PartitionInfo) AggregateUsage() (users []*UserResourceUsage, groups []*UserResourceUsage) {
+	if pi.userGroupTracker == nil {
+		return nil, nil
+	}
+	return pi.userGroupTracker.AllUsage()
+}
@@ -83,7 +83,24 @@ func SetClusterInfoFromConfigFile(clusterInfo *ClusterInfo, rmID string, policyG
 // - update existing partitions
 // - remove deleted partitions
 // updates and add internally are processed differently outside of this method they are the same.
+// A partition that disappears from the config but still has running
+// applications, reserved applications or allocated nodes is left alone and
+// reported as a RemovalBlockedError instead of being marked for removal.
 func UpdateClusterInfoFromConfigFile(clusterInfo *ClusterInfo, rmID string) ([]*PartitionInfo, []*PartitionInfo, error) {
+	return updateClusterInfoFromConfigFile(clusterInfo, rmID, false)
+}
+
+// ForceUpdateClusterInfoFromConfigFile is UpdateClusterInfoFromConfigFile
+// without the RemovalBlockedError/QueueRemovalBlockedError safety check: a
+// partition or queue that disappears from the reloaded config is marked for
+// removal even if it still has running applications, reserved applications
+// or allocated nodes. Only use this when the caller has already confirmed
+// that is the intended outcome.
+func ForceUpdateClusterInfoFromConfigFile(clusterInfo *ClusterInfo, rmID string) ([]*PartitionInfo, []*PartitionInfo, error) {
+	return updateClusterInfoFromConfigFile(clusterInfo, rmID, true)
+}
+
+func updateClusterInfoFromConfigFile(clusterInfo *ClusterInfo, rmID string, force bool) ([]*PartitionInfo, []*PartitionInfo, error) {
 	// we must have partitions set at this point
 	if len(clusterInfo.partitions) == 0 {
 		return []*PartitionInfo{}, []*PartitionInfo{}, fmt.Errorf("RM %s has no active partitions, make sure it is registered", rmID)
@@ -109,9 +126,16 @@ func UpdateClusterInfoFromConfigFile(clusterInfo *ClusterInfo, rmID string) ([]*
 		part, ok := clusterInfo.partitions[p.Name]
 		if ok {
 			// make sure the new info passes all checks
-			_, err = newPartitionInfoInternal(p, rmID, nil)
-			if err != nil {
-				return []*PartitionInfo{}, []*PartitionInfo{}, err
+			shadow, shadowErr := newPartitionInfoInternal(p, rmID, nil)
+			if shadowErr != nil {
+				return []*PartitionInfo{}, []*PartitionInfo{}, shadowErr
+			}
+			// a queue that disappears from the reloaded config but still has
+			// applications running under it must not be silently dropped
+			if !force {
+				if blocked := removedQueueBlockers(part.Root, shadow.Root); len(blocked) > 0 {
+					return []*PartitionInfo{}, []*PartitionInfo{}, &QueueRemovalBlockedError{Blocked: blocked}
+				}
 			}
 			// checks passed perform the real update
 			log.Logger().Info("updating partitions", zap.String("partitionName", partitionName))
@@ -138,6 +162,11 @@ func UpdateClusterInfoFromConfigFile(clusterInfo *ClusterInfo, rmID string) ([]*
 	deletedPartitions := make([]*PartitionInfo, 0)
 	for _, part := range clusterInfo.partitions {
 		if !visited[part.Name] {
+			if !force {
+				if blockers := partitionRemovalBlockers(part); len(blockers) > 0 {
+					return []*PartitionInfo{}, []*PartitionInfo{}, &RemovalBlockedError{Name: part.Name, Blockers: blockers}
+				}
+			}
 			part.markPartitionForRemoval()
 			deletedPartitions = append(deletedPartitions, part)
 			log.Logger().Info("marked partition for removal",
@@ -148,6 +177,156 @@ func UpdateClusterInfoFromConfigFile(clusterInfo *ClusterInfo, rmID string) ([]*
 	return updatedPartitions, deletedPartitions, nil
 }
 
+// QueueDiff describes how a single queue changed as the result of a config reload.
+type QueueDiff struct {
+	QueuePath string
+	Added     bool
+	Removed   bool
+	Updated   bool
+}
+
+// PartitionDiff describes how a single partition, and the queues below it, changed
+// as the result of a config reload.
+type PartitionDiff struct {
+	PartitionName string
+	Added         bool
+	Removed       bool
+	QueueChanges  []*QueueDiff
+}
+
+// ReloadClusterInfo performs a transactional reload of the scheduler configuration.
+// Unlike UpdateClusterInfoFromConfigFile, which mutates partitions as it walks the
+// config, this builds and validates a full shadow copy of every partition first
+// (using newPartitionInfoInternal) before touching anything that is live. Only if
+// every partition in the new config passes validation do we start applying the
+// changes; any failure during validation aborts the whole reload and the live
+// partitions are left exactly as they were.
+//
+// Before a live partition is actually mutated it is paused via pauseAndDrain,
+// so that once the scheduler's cycle loop is wired up to honour isPaused and
+// bracket its cycles with beginCycle/endCycle, updatePartitionDetails is
+// guaranteed never to run concurrently with an allocation decision. The
+// partition is un-paused again once its update has been applied (or has
+// failed).
+//
+// Applying a validated config can still fail at the updatePartitionDetails stage of
+// an individual partition (e.g. a concurrent scheduling event). Since that can only
+// happen after the config has already proven valid, such a failure is treated as
+// fatal: the reload stops applying further partitions and returns the error,
+// leaving the remaining not-yet-applied partitions untouched. It is the caller's
+// responsibility to retry or alert on a partial apply.
+func ReloadClusterInfo(clusterInfo *ClusterInfo, rmID string) ([]*PartitionDiff, error) {
+	if len(clusterInfo.partitions) == 0 {
+		return nil, fmt.Errorf("RM %s has no active partitions, make sure it is registered", rmID)
+	}
+	conf, err := configs.SchedulerConfigLoader(clusterInfo.policyGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	// phase 1: build and validate a shadow partition for every partition in the
+	// new config. Nothing here touches the live ClusterInfo.
+	shadows := make(map[string]*PartitionInfo)
+	for _, p := range conf.Partitions {
+		partitionName := common.GetNormalizedPartitionName(p.Name, rmID)
+		p.Name = partitionName
+		shadow, shadowErr := newPartitionInfoInternal(p, rmID, nil)
+		if shadowErr != nil {
+			return nil, fmt.Errorf("config reload aborted, partition %s failed validation: %v", partitionName, shadowErr)
+		}
+		shadows[partitionName] = shadow
+	}
+	// a partition that disappears from the new config but is still in use
+	// blocks the reload before anything live is touched
+	for _, part := range clusterInfo.partitions {
+		if _, kept := shadows[part.Name]; kept {
+			continue
+		}
+		if blockers := partitionRemovalBlockers(part); len(blockers) > 0 {
+			return nil, &RemovalBlockedError{Name: part.Name, Blockers: blockers}
+		}
+	}
+
+	// phase 2: every partition validated, apply the changes for real.
+	configs.ConfigContext.Set(clusterInfo.policyGroup, conf)
+	log.Logger().Info("reloading cluster config", zap.String("rmID", rmID))
+
+	diffs := make([]*PartitionDiff, 0)
+	visited := map[string]bool{}
+	for _, p := range conf.Partitions {
+		partitionName := common.GetNormalizedPartitionName(p.Name, rmID)
+		p.Name = partitionName
+		visited[partitionName] = true
+
+		part, ok := clusterInfo.partitions[partitionName]
+		if ok {
+			diff := diffPartitionQueues(partitionName, part.Root, shadows[partitionName].Root)
+			release := pauseAndDrain(partitionName)
+			err = part.updatePartitionDetails(p)
+			release()
+			if err != nil {
+				return nil, fmt.Errorf("config reload failed applying partition %s, cluster may be partially updated: %v", partitionName, err)
+			}
+			diffs = append(diffs, diff)
+		} else {
+			clusterInfo.addPartition(partitionName, shadows[partitionName])
+			diffs = append(diffs, &PartitionDiff{PartitionName: partitionName, Added: true})
+		}
+		log.Logger().Info("reloaded partition", zap.String("partitionName", partitionName))
+	}
+
+	for _, part := range clusterInfo.partitions {
+		if !visited[part.Name] {
+			part.markPartitionForRemoval()
+			diffs = append(diffs, &PartitionDiff{PartitionName: part.Name, Removed: true})
+			log.Logger().Info("marked partition for removal", zap.String("partitionName", part.Name))
+		}
+	}
+
+	return diffs, nil
+}
+
+// diffPartitionQueues walks the old and new queue trees of a partition and reports
+// which queues were added, removed or updated. Queues are matched by their full
+// path (the only stable identity a queue has across a reload).
+func diffPartitionQueues(partitionName string, oldRoot, newRoot *QueueInfo) *PartitionDiff {
+	oldPaths := make(map[string]*QueueInfo)
+	collectQueuePaths(oldRoot, oldPaths)
+	newPaths := make(map[string]*QueueInfo)
+	collectQueuePaths(newRoot, newPaths)
+
+	diff := &PartitionDiff{PartitionName: partitionName}
+	for path, newQueue := range newPaths {
+		oldQueue, existed := oldPaths[path]
+		if !existed {
+			diff.QueueChanges = append(diff.QueueChanges, &QueueDiff{QueuePath: path, Added: true})
+			continue
+		}
+		if !resources.Equals(oldQueue.guaranteedResource, newQueue.guaranteedResource) ||
+			!resources.Equals(oldQueue.maxResource, newQueue.maxResource) {
+			diff.QueueChanges = append(diff.QueueChanges, &QueueDiff{QueuePath: path, Updated: true})
+		}
+	}
+	for path := range oldPaths {
+		if _, stillExists := newPaths[path]; !stillExists {
+			diff.QueueChanges = append(diff.QueueChanges, &QueueDiff{QueuePath: path, Removed: true})
+		}
+	}
+	return diff
+}
+
+// collectQueuePaths flattens a queue tree into a map keyed by full dotted path
+// (root.parent.child).
+func collectQueuePaths(queue *QueueInfo, paths map[string]*QueueInfo) {
+	if queue == nil {
+		return
+	}
+	paths[queue.Name] = queue
+	for _, child := range queue.children {
+		collectQueuePaths(child, paths)
+	}
+}
+
 // Create a new checked PartitionInfo
 // convenience method that wraps creation and checking the settings.
 func newPartitionInfoInternal(part configs.PartitionConfig, rmID string, info *ClusterInfo) (*PartitionInfo, error) {
@@ -160,6 +339,23 @@ func newPartitionInfoInternal(part configs.PartitionConfig, rmID string, info *C
 	if err != nil {
 		return nil, err
 	}
+	// build and validate the placement rule chain against the queues we just built
+	placementManager, err := newPlacementManager(part.PlacementRules, partition.Root)
+	if err != nil {
+		return nil, err
+	}
+	partition.placementManager = placementManager
+
+	// build the user/group quota tracker from the partition's configured limits
+	userGroupTracker, err := newUserGroupTracker(part.Limits)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkUserGroupLimitsFitQueues(part.Limits, partition.Root); err != nil {
+		return nil, err
+	}
+	partition.userGroupTracker = userGroupTracker
+
 	return partition, nil
 }
 
@@ -167,8 +363,14 @@ func newPartitionInfoInternal(part configs.PartitionConfig, rmID string, info *C
 // This is lock free and not protected against race conditions as it operates on a private new structure.
 // - child or children cannot have higher maximum or guaranteed limits than parents
 // - children (added together) cannot have a higher guaranteed setting than a parent
-// TODO add maximum number of running applications
+// - a child's MaxApplications cannot exceed its parent's (0 means unlimited)
 func checkResourceConfigurationsForQueue(cur *QueueInfo, parent *QueueInfo) error {
+	if parent == nil {
+		resolveMaxApplicationsForQueue(cur)
+	}
+	if err := checkMaxApplicationsForQueue(cur, parent); err != nil {
+		return err
+	}
 	// If cur has children, make sure sum of children's guaranteed <= cur.guaranteed
 	if len(cur.children) > 0 {
 		// Check children
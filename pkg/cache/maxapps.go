@@ -0,0 +1,79 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "fmt"
+
+// resolveMaxApplicationsForQueue fills in the effective MaxApplications for
+// cur and every queue below it, bottom-up: a queue with MaxApplications 0
+// and children inherits the sum of its children's already-resolved
+// MaxApplications, so a multi-level chain of inheriting queues ends up with
+// the right effective ceiling instead of only the immediate children being
+// accounted for. Must run, once, before any checkMaxApplicationsForQueue
+// call, since that check compares already-resolved values.
+func resolveMaxApplicationsForQueue(cur *QueueInfo) {
+	for _, child := range cur.children {
+		resolveMaxApplicationsForQueue(child)
+	}
+	if cur.MaxApplications == 0 && len(cur.children) > 0 {
+		sum := 0
+		for _, child := range cur.children {
+			sum += child.MaxApplications
+		}
+		cur.MaxApplications = sum
+	}
+}
+
+// checkMaxApplicationsForQueue validates the resolved MaxApplications setting
+// of cur against its parent. A child's MaxApplications cannot exceed its
+// parent's. Children are allowed to sum to more than the parent's
+// MaxApplications (oversubscription is fine for a soft application count,
+// unlike resources): the parent limit still applies across all of its
+// descendants combined at submission time.
+func checkMaxApplicationsForQueue(cur *QueueInfo, parent *QueueInfo) error {
+	if parent != nil && parent.MaxApplications > 0 && cur.MaxApplications > parent.MaxApplications {
+		return fmt.Errorf("queue %s has maxapplications (%d) set larger than parent's maxapplications (%d)", cur.Name, cur.MaxApplications, parent.MaxApplications)
+	}
+	return nil
+}
+
+// assertMaxApplicationsNotExceeded checks the queue and every ancestor for the
+// maximum number of running applications before a new application is
+// admitted. It is called from PartitionInfo.AddNewApplication, so that a
+// queue or any of its ancestors sitting at its configured limit rejects the
+// submission instead of silently oversubscribing.
+func assertMaxApplicationsNotExceeded(queue *QueueInfo) error {
+	for q := queue; q != nil; q = q.parent {
+		if q.MaxApplications > 0 && q.RunningApplications() >= q.MaxApplications {
+			return fmt.Errorf("queue %s is at its maximum number of running applications (%d)", q.Name, q.MaxApplications)
+		}
+	}
+	return nil
+}
+
+// RunningApplications returns the number of applications currently running
+// in this queue and every queue below it, for the MaxApplications check and
+// the queue applications DAO.
+func (q *QueueInfo) RunningApplications() int {
+	count := len(q.applications)
+	for _, child := range q.children {
+		count += child.RunningApplications()
+	}
+	return count
+}
@@ -0,0 +1,50 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "testing"
+
+// TestResolveMaxApplicationsForQueueMultiLevel verifies that inheritance is
+// computed bottom-up: an intermediate queue that also relies on inheritance
+// must have its own effective MaxApplications resolved before its parent
+// sums it, otherwise a 3+ level hierarchy silently ends up "unlimited".
+func TestResolveMaxApplicationsForQueueMultiLevel(t *testing.T) {
+	leaf1 := &QueueInfo{Name: "root.mid.leaf1", MaxApplications: 5, children: map[string]*QueueInfo{}}
+	leaf2 := &QueueInfo{Name: "root.mid.leaf2", MaxApplications: 5, children: map[string]*QueueInfo{}}
+	mid := &QueueInfo{Name: "root.mid", MaxApplications: 0, children: map[string]*QueueInfo{"leaf1": leaf1, "leaf2": leaf2}}
+	root := &QueueInfo{Name: "root", MaxApplications: 0, children: map[string]*QueueInfo{"mid": mid}}
+
+	resolveMaxApplicationsForQueue(root)
+
+	if mid.MaxApplications != 10 {
+		t.Fatalf("expected root.mid to inherit 10 from its leaves, got %d", mid.MaxApplications)
+	}
+	if root.MaxApplications != 10 {
+		t.Fatalf("expected root to inherit 10 via root.mid, got %d", root.MaxApplications)
+	}
+}
+
+func TestCheckMaxApplicationsForQueueRejectsChildExceedingParent(t *testing.T) {
+	parent := &QueueInfo{Name: "root", MaxApplications: 5}
+	child := &QueueInfo{Name: "root.default", MaxApplications: 10}
+
+	if err := checkMaxApplicationsForQueue(child, parent); err == nil {
+		t.Fatal("expected an error when a child's maxapplications exceeds its parent's")
+	}
+}
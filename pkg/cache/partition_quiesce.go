@@ -0,0 +1,90 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "sync"
+
+// partitionQuiesce tracks whether a partition has been paused for an
+// in-flight config reload, and lets ReloadClusterInfo wait for any
+// scheduling cycle already in progress to finish before it starts mutating
+// the partition. State is keyed by partition name rather than carried on
+// PartitionInfo itself, since a partition can be paused and replaced (on a
+// reload that recreates it) without losing track of an in-flight cycle.
+type partitionQuiesce struct {
+	mu     sync.Mutex
+	paused bool
+	cycles sync.WaitGroup
+}
+
+// quiesceStates holds one partitionQuiesce per partition name, created on
+// first use.
+var quiesceStates sync.Map // map[string]*partitionQuiesce
+
+func quiesceFor(partitionName string) *partitionQuiesce {
+	v, _ := quiesceStates.LoadOrStore(partitionName, &partitionQuiesce{})
+	return v.(*partitionQuiesce)
+}
+
+// pauseAndDrain marks the named partition as paused and blocks until any
+// scheduling cycle already in flight (tracked via beginCycle/endCycle)
+// finishes, so a config reload never mutates a partition concurrently with
+// an allocation decision. It returns a release func that un-pauses the
+// partition; the caller must call it once the reload has applied the new
+// config, whether or not that application succeeded.
+//
+// isPaused, beginCycle and endCycle are the primitives the scheduler's
+// cycle loop is expected to call (isPaused before starting a cycle,
+// beginCycle/endCycle bracketing it); that loop lives outside pkg/cache, so
+// until it is wired up, pauseAndDrain only ever waits on cycles nothing has
+// registered and returns immediately.
+func pauseAndDrain(partitionName string) func() {
+	q := quiesceFor(partitionName)
+	q.mu.Lock()
+	q.paused = true
+	q.mu.Unlock()
+
+	q.cycles.Wait()
+
+	return func() {
+		q.mu.Lock()
+		q.paused = false
+		q.mu.Unlock()
+	}
+}
+
+// isPaused reports whether partitionName is currently paused for a reload,
+// for the scheduler's cycle loop to check before it starts a new scheduling
+// cycle for the partition (see pauseAndDrain).
+func isPaused(partitionName string) bool {
+	q := quiesceFor(partitionName)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.paused
+}
+
+// beginCycle and endCycle bracket one scheduling cycle for partitionName, so
+// pauseAndDrain can wait for cycles already running to finish instead of
+// racing a config reload against live allocation decisions.
+func beginCycle(partitionName string) {
+	quiesceFor(partitionName).cycles.Add(1)
+}
+
+func endCycle(partitionName string) {
+	quiesceFor(partitionName).cycles.Done()
+}
@@ -0,0 +1,68 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPauseAndDrainWaitsForInFlightCycle(t *testing.T) {
+	partitionName := "test-pause-and-drain"
+	beginCycle(partitionName)
+
+	done := make(chan struct{})
+	go func() {
+		release := pauseAndDrain(partitionName)
+		release()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("pauseAndDrain returned before the in-flight cycle ended")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	endCycle(partitionName)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("pauseAndDrain did not return after the in-flight cycle ended")
+	}
+}
+
+func TestIsPausedReflectsPauseAndRelease(t *testing.T) {
+	partitionName := "test-is-paused"
+
+	if isPaused(partitionName) {
+		t.Fatal("partition should not start out paused")
+	}
+
+	release := pauseAndDrain(partitionName)
+	if !isPaused(partitionName) {
+		t.Fatal("partition should be paused after pauseAndDrain")
+	}
+
+	release()
+	if isPaused(partitionName) {
+		t.Fatal("partition should no longer be paused after release")
+	}
+}
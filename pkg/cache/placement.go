@@ -0,0 +1,227 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/common/configs"
+)
+
+// PlacementRule decides, or declines to decide, which queue an application
+// should be placed into. It replaces the implicit behaviour of just trusting
+// the queue path the request came in with: a partition now always has an
+// explicit, validated chain of PlacementRule that is walked in order until one
+// of them places the application.
+type PlacementRule interface {
+	// placeApplication returns the full queue path the application should be
+	// submitted to. A rule that does not match the application returns an
+	// empty path and a nil error so the next rule in the chain gets a turn.
+	placeApplication(app *ApplicationInfo) (string, error)
+
+	// name identifies the rule, used in error messages and the DAO.
+	name() string
+}
+
+// PlacementManager owns the ordered rule chain for one partition and is the
+// single entry point placement-aware callers use.
+type PlacementManager struct {
+	rules []PlacementRule
+}
+
+// newPlacementManager builds and validates the rule chain described by conf.
+// The implicit recovery rule is always appended last so a recovering
+// application is always placeable even if every configured rule declines it.
+func newPlacementManager(conf []configs.PlacementRule, queues *QueueInfo) (*PlacementManager, error) {
+	rules := make([]PlacementRule, 0, len(conf)+1)
+	for _, ruleConf := range conf {
+		rule, err := buildPlacementRule(ruleConf)
+		if err != nil {
+			return nil, err
+		}
+		if err = validatePlacementRule(rule, ruleConf, queues); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	rules = append(rules, newRecoveryRule())
+
+	return &PlacementManager{rules: rules}, nil
+}
+
+// PlaceApplication walks the rule chain in order and returns the first queue
+// path a rule claims for the application. Because the recovery rule is always
+// appended last and never declines, this only returns an error if a rule
+// itself fails (e.g. a malformed queue path), not because nothing matched.
+func (pm *PlacementManager) PlaceApplication(app *ApplicationInfo) (string, error) {
+	for _, rule := range pm.rules {
+		queuePath, err := rule.placeApplication(app)
+		if err != nil {
+			return "", fmt.Errorf("placement rule %s failed for application %s: %v", rule.name(), app.ApplicationID, err)
+		}
+		if queuePath != "" {
+			return queuePath, nil
+		}
+	}
+	return "", fmt.Errorf("no placement rule matched application %s", app.ApplicationID)
+}
+
+// RuleNames returns the active rule chain in order, for the placement rules
+// DAO. The rule chain is not included in the state dump.
+func (pm *PlacementManager) RuleNames() []string {
+	names := make([]string, len(pm.rules))
+	for i, rule := range pm.rules {
+		names[i] = rule.name()
+	}
+	return names
+}
+
+// buildPlacementRule constructs the concrete PlacementRule for one configured rule.
+func buildPlacementRule(ruleConf configs.PlacementRule) (PlacementRule, error) {
+	switch ruleConf.Name {
+	case "user":
+		return newUserRule(ruleConf), nil
+	case "provided":
+		return newProvidedRule(ruleConf), nil
+	case "tag":
+		return newTagRule(ruleConf), nil
+	case "fixed":
+		return newFixedRule(ruleConf), nil
+	case "recovery":
+		return newRecoveryRule(), nil
+	default:
+		return nil, fmt.Errorf("unknown placement rule %s", ruleConf.Name)
+	}
+}
+
+// validatePlacementRule makes sure a rule chain terminates on real queues: any
+// queue path it can statically resolve (a fixed rule's queue, or a rule's
+// configured parent) must already exist under queues unless the rule is
+// marked create:true.
+func validatePlacementRule(rule PlacementRule, ruleConf configs.PlacementRule, queues *QueueInfo) error {
+	if ruleConf.Parent != "" && !ruleConf.Create {
+		if FindQueueByPath(queues, ruleConf.Parent) == nil {
+			return fmt.Errorf("placement rule %s references parent queue %s which does not exist and is not marked create:true", rule.name(), ruleConf.Parent)
+		}
+	}
+	if ruleConf.Name == "fixed" {
+		if ruleConf.Value == "" {
+			return fmt.Errorf("placement rule fixed requires a queue value")
+		}
+		if !ruleConf.Create && FindQueueByPath(queues, ruleConf.Value) == nil {
+			return fmt.Errorf("placement rule fixed references queue %s which does not exist and is not marked create:true", ruleConf.Value)
+		}
+	}
+	return nil
+}
+
+// userRule places an application into a queue named after the submitting user,
+// under the configured parent (or root if none was set).
+type userRule struct {
+	parent string
+}
+
+func newUserRule(conf configs.PlacementRule) *userRule {
+	return &userRule{parent: conf.Parent}
+}
+
+func (r *userRule) placeApplication(app *ApplicationInfo) (string, error) {
+	if app.User == "" {
+		return "", nil
+	}
+	if r.parent != "" {
+		return r.parent + "." + app.User, nil
+	}
+	return "root." + app.User, nil
+}
+
+func (r *userRule) name() string { return "user" }
+
+// providedRule accepts the queue the request already asked for, if any. This is
+// the old implicit behaviour, now made an explicit, opt-in rule.
+type providedRule struct{}
+
+func newProvidedRule(_ configs.PlacementRule) *providedRule {
+	return &providedRule{}
+}
+
+func (r *providedRule) placeApplication(app *ApplicationInfo) (string, error) {
+	return app.QueueName, nil
+}
+
+func (r *providedRule) name() string { return "provided" }
+
+// tagRule places an application based on the value of a configured application
+// tag (e.g. a namespace or label carried over from the RM).
+type tagRule struct {
+	tagName string
+	parent  string
+}
+
+func newTagRule(conf configs.PlacementRule) *tagRule {
+	return &tagRule{tagName: conf.Value, parent: conf.Parent}
+}
+
+func (r *tagRule) placeApplication(app *ApplicationInfo) (string, error) {
+	value, ok := app.Tags[r.tagName]
+	if !ok || value == "" {
+		return "", nil
+	}
+	if r.parent != "" {
+		return r.parent + "." + value, nil
+	}
+	return value, nil
+}
+
+func (r *tagRule) name() string { return "tag" }
+
+// fixedRule always sends every application that reaches it to the same,
+// pre-validated queue.
+type fixedRule struct {
+	queuePath string
+}
+
+func newFixedRule(conf configs.PlacementRule) *fixedRule {
+	return &fixedRule{queuePath: conf.Value}
+}
+
+func (r *fixedRule) placeApplication(_ *ApplicationInfo) (string, error) {
+	return r.queuePath, nil
+}
+
+func (r *fixedRule) name() string { return "fixed" }
+
+// recoveryRule is the always-present last resort, reached only when every
+// configured rule before it declines: an application that is recovering after
+// a scheduler restart carries the queue it was already running in and lands
+// back there; an application with no queue lands in root.default.
+type recoveryRule struct{}
+
+func newRecoveryRule() *recoveryRule {
+	return &recoveryRule{}
+}
+
+func (r *recoveryRule) placeApplication(app *ApplicationInfo) (string, error) {
+	if app.QueueName == "" {
+		return "root.default", nil
+	}
+	return app.QueueName, nil
+}
+
+func (r *recoveryRule) name() string { return "recovery" }
@@ -0,0 +1,47 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "testing"
+
+// TestAddNewApplicationUsesPlacementManager verifies that, once a partition
+// has a placement manager, AddNewApplication places the application using
+// the configured rule chain instead of trusting the queue path the request
+// came in with.
+func TestAddNewApplicationUsesPlacementManager(t *testing.T) {
+	userQueue := &QueueInfo{Name: "root.bob", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{}}
+	root := &QueueInfo{Name: "root", children: map[string]*QueueInfo{"bob": userQueue}, applications: map[string]*ApplicationInfo{}}
+	pi := &PartitionInfo{
+		Root:             root,
+		applications:     map[string]*ApplicationInfo{},
+		placementManager: &PlacementManager{rules: []PlacementRule{&userRule{}}},
+	}
+
+	app := &ApplicationInfo{ApplicationID: "app-1", QueueName: "root.ignored", User: "bob"}
+	if err := pi.AddNewApplication(app); err != nil {
+		t.Fatalf("expected application to be placed successfully, got: %v", err)
+	}
+
+	if app.QueueName != "root.bob" {
+		t.Fatalf("expected the application to be placed onto root.bob, got %s", app.QueueName)
+	}
+	if _, ok := userQueue.applications[app.ApplicationID]; !ok {
+		t.Fatal("expected the application to be tracked on the placed queue, not the requested one")
+	}
+}
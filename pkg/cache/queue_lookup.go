@@ -0,0 +1,42 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "strings"
+
+// FindQueueByPath walks down from root following the dotted queue path
+// (root.parent.child) and returns the matching QueueInfo, or nil if any
+// segment along the way does not exist. It is used by the placement rule
+// validation to check whether a rule's target queue already exists before
+// deciding whether create:true is required.
+func FindQueueByPath(root *QueueInfo, path string) *QueueInfo {
+	if root == nil || path == "" {
+		return nil
+	}
+	parts := strings.Split(path, ".")
+	cur := root
+	for i := 1; i < len(parts); i++ {
+		next, ok := cur.children[parts[i]]
+		if !ok {
+			return nil
+		}
+		cur = next
+	}
+	return cur
+}
@@ -0,0 +1,32 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "testing"
+
+// TestReloadClusterInfoRequiresRegisteredPartitions covers ReloadClusterInfo's
+// guard clause: reloading before an RM has registered any partition (e.g. via
+// SetClusterInfoFromConfigFile) must fail instead of loading a config nothing
+// is running yet.
+func TestReloadClusterInfoRequiresRegisteredPartitions(t *testing.T) {
+	clusterInfo := &ClusterInfo{}
+	if _, err := ReloadClusterInfo(clusterInfo, "rm-1"); err == nil {
+		t.Fatal("expected an error reloading a cluster with no registered partitions")
+	}
+}
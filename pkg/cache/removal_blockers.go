@@ -0,0 +1,114 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "fmt"
+
+// RemovalBlocker enumerates the reasons a partition or queue cannot be
+// silently removed: something on it is still in use.
+type RemovalBlocker string
+
+const (
+	BlockerApps             RemovalBlocker = "apps"
+	BlockerAllocations      RemovalBlocker = "allocations"
+	BlockerReservations     RemovalBlocker = "reservations"
+	BlockerChildQueuesInUse RemovalBlocker = "child-queues-in-use"
+)
+
+// RemovalBlockedError is returned instead of silently marking a partition or
+// queue for removal when it still has something in use. The caller (RM event
+// handling, REST) can list Blockers to the operator instead of just a string.
+type RemovalBlockedError struct {
+	Name     string
+	Blockers []RemovalBlocker
+}
+
+func (e *RemovalBlockedError) Error() string {
+	return fmt.Sprintf("%s still in use, refusing to remove (blockers: %v)", e.Name, e.Blockers)
+}
+
+// partitionRemovalBlockers reports what is still in use on a partition that
+// has disappeared from the config and would otherwise be marked for removal.
+func partitionRemovalBlockers(part *PartitionInfo) []RemovalBlocker {
+	blockers := make([]RemovalBlocker, 0)
+	if len(part.applications) > 0 {
+		blockers = append(blockers, BlockerApps)
+	}
+	if len(part.reservedApps) > 0 {
+		blockers = append(blockers, BlockerReservations)
+	}
+	if len(part.nodes) > 0 {
+		blockers = append(blockers, BlockerAllocations)
+	}
+	return blockers
+}
+
+// queueRemovalBlockers reports what is still in use on a queue that has
+// disappeared from the config and would otherwise be removed from its parent.
+// Called from removedQueueBlockers for every queue that is no longer present
+// in the reloaded config.
+func queueRemovalBlockers(queue *QueueInfo) []RemovalBlocker {
+	blockers := make([]RemovalBlocker, 0)
+	if len(queue.applications) > 0 {
+		blockers = append(blockers, BlockerApps)
+	}
+	for _, child := range queue.children {
+		if len(queueRemovalBlockers(child)) > 0 {
+			blockers = append(blockers, BlockerChildQueuesInUse)
+			break
+		}
+	}
+	return blockers
+}
+
+// QueueRemovalBlockedError is returned instead of silently dropping a queue
+// when one or more queues that disappeared from a reloaded config still have
+// applications running under them. Blocked maps each affected queue's full
+// path to the reasons it cannot be removed.
+type QueueRemovalBlockedError struct {
+	Blocked map[string][]RemovalBlocker
+}
+
+func (e *QueueRemovalBlockedError) Error() string {
+	return fmt.Sprintf("queues still in use, refusing to remove: %v", e.Blocked)
+}
+
+// removedQueueBlockers compares the live queue tree against the queue tree a
+// reloaded config would produce and reports, for every queue that
+// disappeared, what is still keeping it in use. Called from
+// UpdateClusterInfoFromConfigFile before a partition update is applied, so a
+// queue that still has running applications blocks the whole reload instead
+// of being silently dropped by updatePartitionDetails.
+func removedQueueBlockers(oldRoot, newRoot *QueueInfo) map[string][]RemovalBlocker {
+	oldPaths := make(map[string]*QueueInfo)
+	collectQueuePaths(oldRoot, oldPaths)
+	newPaths := make(map[string]*QueueInfo)
+	collectQueuePaths(newRoot, newPaths)
+
+	blocked := make(map[string][]RemovalBlocker)
+	for path, queue := range oldPaths {
+		if _, stillExists := newPaths[path]; stillExists {
+			continue
+		}
+		if blockers := queueRemovalBlockers(queue); len(blockers) > 0 {
+			blocked[path] = blockers
+		}
+	}
+	return blocked
+}
@@ -0,0 +1,41 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import "testing"
+
+func TestRemovedQueueBlockersReportsQueuesStillInUse(t *testing.T) {
+	busyChild := &QueueInfo{Name: "root.busy", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{"app-1": {}}}
+	idleChild := &QueueInfo{Name: "root.idle", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{}}
+	oldRoot := &QueueInfo{Name: "root", children: map[string]*QueueInfo{"busy": busyChild, "idle": idleChild}, applications: map[string]*ApplicationInfo{}}
+
+	// the reloaded config drops both child queues
+	newRoot := &QueueInfo{Name: "root", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{}}
+
+	blocked := removedQueueBlockers(oldRoot, newRoot)
+	if len(blocked) != 1 {
+		t.Fatalf("expected exactly 1 blocked queue, got %d: %v", len(blocked), blocked)
+	}
+	if _, ok := blocked["root.busy"]; !ok {
+		t.Fatalf("expected root.busy to be reported as blocked, got %v", blocked)
+	}
+	if _, ok := blocked["root.idle"]; ok {
+		t.Fatalf("root.idle has no applications and should not be blocked, got %v", blocked)
+	}
+}
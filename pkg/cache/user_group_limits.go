@@ -0,0 +1,294 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/common/configs"
+	"github.com/apache/incubator-yunikorn-core/pkg/common/resources"
+)
+
+// entityLimit is one configured maxresources/maxapplications ceiling, scoped to
+// a queue path (empty means the whole partition).
+type entityLimit struct {
+	queuePath       string
+	maxResources    *resources.Resource
+	maxApplications int
+}
+
+// entityUsage is the live, mutable counterpart to entityLimit: what a user or
+// group is actually consuming right now, broken down by queue.
+type entityUsage struct {
+	usedResources map[string]*resources.Resource
+	runningApps   map[string]int
+}
+
+// UserGroupTracker accumulates per-user and per-group usage for one partition
+// and enforces the configured limits. It is built once, in
+// newPartitionInfoInternal, from configs.PartitionConfig.Limits, and is then
+// consulted on every application add (PartitionInfo.AddNewApplication) for
+// the lifetime of the partition. No allocation ask path in this package
+// consults it.
+type UserGroupTracker struct {
+	sync.RWMutex
+	userLimits  map[string][]*entityLimit
+	groupLimits map[string][]*entityLimit
+	userUsage   map[string]*entityUsage
+	groupUsage  map[string]*entityUsage
+}
+
+// newUserGroupTracker builds the limit tables for a partition from its
+// configured Limits entries. It does not itself validate that a user/group
+// maxresources fits inside the queue's max resources; that is done by
+// checkUserGroupLimitsFitQueues, next to checkResourceConfigurationsForQueue
+// in newPartitionInfoInternal.
+func newUserGroupTracker(limitsConf []configs.Limit) (*UserGroupTracker, error) {
+	t := &UserGroupTracker{
+		userLimits:  make(map[string][]*entityLimit),
+		groupLimits: make(map[string][]*entityLimit),
+		userUsage:   make(map[string]*entityUsage),
+		groupUsage:  make(map[string]*entityUsage),
+	}
+
+	for _, l := range limitsConf {
+		maxRes, err := resources.NewResourceFromConf(l.MaxResources)
+		if err != nil {
+			return nil, fmt.Errorf("limit for queue %s has an invalid maxresources entry: %v", l.QueuePath, err)
+		}
+		limit := &entityLimit{queuePath: l.QueuePath, maxResources: maxRes, maxApplications: l.MaxApplications}
+		for _, user := range l.Users {
+			t.userLimits[user] = append(t.userLimits[user], limit)
+		}
+		for _, group := range l.Groups {
+			t.groupLimits[group] = append(t.groupLimits[group], limit)
+		}
+	}
+
+	return t, nil
+}
+
+// checkUserGroupLimits verifies that admitting one more application (or ask)
+// for this user, in this queue, would not push the user or any of their
+// groups over their configured ceiling. delta is the resource the
+// application/ask would add; countsAsNewApp is true when called from
+// application admission rather than an allocation ask.
+func (t *UserGroupTracker) checkUserGroupLimits(user string, groups []string, queuePath string, delta *resources.Resource, countsAsNewApp bool) error {
+	t.RLock()
+	defer t.RUnlock()
+
+	if err := t.checkEntity(user, t.userLimits[user], t.userUsage[user], queuePath, delta, countsAsNewApp); err != nil {
+		return fmt.Errorf("user %s: %v", user, err)
+	}
+	for _, group := range groups {
+		if err := t.checkEntity(group, t.groupLimits[group], t.groupUsage[group], queuePath, delta, countsAsNewApp); err != nil {
+			return fmt.Errorf("group %s: %v", group, err)
+		}
+	}
+	return nil
+}
+
+// isQueueOrDescendant reports whether queuePath is ancestorPath itself, or one
+// of its descendants. An empty ancestorPath matches every queue in the
+// partition, the same way an entityLimit with no QueuePath set applies
+// partition-wide.
+func isQueueOrDescendant(ancestorPath, queuePath string) bool {
+	if ancestorPath == "" || ancestorPath == queuePath {
+		return true
+	}
+	return strings.HasPrefix(queuePath, ancestorPath+".")
+}
+
+// checkEntity applies every limit configured for a single user or group name
+// against its current recorded usage. A limit scoped to a queue applies
+// across that queue's whole subtree, not just to applications submitted
+// directly to it, so usage is aggregated over every recorded queue path at or
+// below the limit's queue before comparing against the ceiling.
+func (t *UserGroupTracker) checkEntity(name string, limits []*entityLimit, usage *entityUsage, queuePath string, delta *resources.Resource, countsAsNewApp bool) error {
+	if len(limits) == 0 {
+		return nil
+	}
+	for _, limit := range limits {
+		if limit.queuePath != "" && !isQueueOrDescendant(limit.queuePath, queuePath) {
+			continue
+		}
+		usedResources, runningApps := resources.NewResource(), 0
+		if usage != nil {
+			usedResources, runningApps = usage.usageAtOrBelow(limit.queuePath)
+		}
+		if limit.maxApplications > 0 && countsAsNewApp && runningApps+1 > limit.maxApplications {
+			return fmt.Errorf("would exceed maxapplications (%d) on queue %s", limit.maxApplications, queuePath)
+		}
+		if limit.maxResources != nil {
+			projected := usedResources.Clone()
+			projected.AddTo(delta)
+			if !resources.FitIn(limit.maxResources, projected) {
+				return fmt.Errorf("would exceed maxresources (%v) on queue %s", limit.maxResources, queuePath)
+			}
+		}
+	}
+	return nil
+}
+
+// recordUsage updates the live usage counters after an application or ask has
+// actually been admitted. Must be called with the same arguments that were
+// just successfully checked by checkUserGroupLimits.
+func (t *UserGroupTracker) recordUsage(user string, groups []string, queuePath string, delta *resources.Resource, newApp bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.applyUsage(t.userUsage, user, queuePath, delta, newApp)
+	for _, group := range groups {
+		t.applyUsage(t.groupUsage, group, queuePath, delta, newApp)
+	}
+}
+
+// applyUsage records delta against the exact queuePath an application or ask
+// was admitted to, not every ancestor above it: usageAtOrBelow aggregates
+// across descendants on demand when a limit is checked, so summing every
+// stored path here would double-count against a partition-wide or
+// higher-level limit.
+func (t *UserGroupTracker) applyUsage(table map[string]*entityUsage, name string, queuePath string, delta *resources.Resource, newApp bool) {
+	usage, ok := table[name]
+	if !ok {
+		usage = &entityUsage{usedResources: make(map[string]*resources.Resource), runningApps: make(map[string]int)}
+		table[name] = usage
+	}
+	if existing, ok := usage.usedResources[queuePath]; ok {
+		existing.AddTo(delta)
+	} else {
+		usage.usedResources[queuePath] = delta.Clone()
+	}
+	if newApp {
+		usage.runningApps[queuePath]++
+	}
+}
+
+// usageAtOrBelow sums the resources used, and applications running, across
+// every queue path recorded for this entity that is queuePath itself or one
+// of its descendants, so a limit scoped to root.dept aggregates usage
+// recorded at root.dept.team1 instead of missing it on an exact match.
+func (u *entityUsage) usageAtOrBelow(queuePath string) (*resources.Resource, int) {
+	total := resources.NewResource()
+	apps := 0
+	for path, used := range u.usedResources {
+		if isQueueOrDescendant(queuePath, path) {
+			total.AddTo(used)
+		}
+	}
+	for path, count := range u.runningApps {
+		if isQueueOrDescendant(queuePath, path) {
+			apps += count
+		}
+	}
+	return total, apps
+}
+
+// assertUserGroupLimitsNotExceeded is the enforcement hook called from
+// application admission (PartitionInfo.AddNewApplication) before a request is
+// allowed to proceed: it rejects the request outright rather than letting it
+// sit queued forever against a limit it can never satisfy. No allocation ask
+// path in this package calls it.
+func assertUserGroupLimitsNotExceeded(tracker *UserGroupTracker, user string, groups []string, queuePath string, delta *resources.Resource, newApp bool) error {
+	if tracker == nil {
+		return nil
+	}
+	return tracker.checkUserGroupLimits(user, groups, queuePath, delta, newApp)
+}
+
+// checkUserGroupLimitsFitQueues makes sure no configured user/group
+// maxresources entry asks for more than the queue it is scoped to can ever
+// grant. This runs alongside checkResourceConfigurationsForQueue so a bad
+// limits entry is caught at the same point a bad queue resource setting is.
+func checkUserGroupLimitsFitQueues(limitsConf []configs.Limit, root *QueueInfo) error {
+	for _, l := range limitsConf {
+		if l.QueuePath == "" {
+			continue
+		}
+		queue := FindQueueByPath(root, l.QueuePath)
+		if queue == nil {
+			return fmt.Errorf("limit references queue %s which does not exist", l.QueuePath)
+		}
+		maxRes, err := resources.NewResourceFromConf(l.MaxResources)
+		if err != nil {
+			return fmt.Errorf("limit for queue %s has an invalid maxresources entry: %v", l.QueuePath, err)
+		}
+		if queue.maxResource != nil && !resources.FitIn(queue.maxResource, maxRes) {
+			return fmt.Errorf("limit for queue %s has maxresources (%v) larger than the queue's max resources (%v)", l.QueuePath, maxRes, queue.maxResource)
+		}
+	}
+	return nil
+}
+
+// UserResourceUsage is a per-queue usage breakdown plus the partition-wide
+// total, returned to the /usage/user/:name and /usage/group/:name REST calls.
+type UserResourceUsage struct {
+	Name        string
+	PerQueue    map[string]*resources.Resource
+	Total       *resources.Resource
+	RunningApps int
+}
+
+// GetUserUsage returns the recorded usage for a user, or an empty usage if the
+// user has nothing running in this partition.
+func (t *UserGroupTracker) GetUserUsage(name string) *UserResourceUsage {
+	t.RLock()
+	defer t.RUnlock()
+	return summarizeUsage(name, t.userUsage[name])
+}
+
+// GetGroupUsage returns the recorded usage for a group, or an empty usage if
+// the group has nothing running in this partition.
+func (t *UserGroupTracker) GetGroupUsage(name string) *UserResourceUsage {
+	t.RLock()
+	defer t.RUnlock()
+	return summarizeUsage(name, t.groupUsage[name])
+}
+
+// AllUsage returns every user and every group with recorded usage in this
+// partition, for the aggregate partition usage view served at
+// /ws/v1/partition/:partition/usage.
+func (t *UserGroupTracker) AllUsage() (users []*UserResourceUsage, groups []*UserResourceUsage) {
+	t.RLock()
+	defer t.RUnlock()
+	for name := range t.userUsage {
+		users = append(users, summarizeUsage(name, t.userUsage[name]))
+	}
+	for name := range t.groupUsage {
+		groups = append(groups, summarizeUsage(name, t.groupUsage[name]))
+	}
+	return users, groups
+}
+
+func summarizeUsage(name string, usage *entityUsage) *UserResourceUsage {
+	result := &UserResourceUsage{Name: name, PerQueue: make(map[string]*resources.Resource), Total: resources.NewResource()}
+	if usage == nil {
+		return result
+	}
+	for queuePath, used := range usage.usedResources {
+		result.PerQueue[queuePath] = used
+		result.Total.AddTo(used)
+	}
+	for _, count := range usage.runningApps {
+		result.RunningApps += count
+	}
+	return result
+}
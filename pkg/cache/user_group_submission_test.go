@@ -0,0 +1,79 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/common/configs"
+)
+
+// TestAddNewApplicationRejectsOverUserQuota verifies that admission is
+// rejected, and usage is not recorded, when it would push the submitting
+// user over their configured maxapplications quota.
+func TestAddNewApplicationRejectsOverUserQuota(t *testing.T) {
+	leaf := &QueueInfo{Name: "root.default", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{}}
+	root := &QueueInfo{Name: "root", children: map[string]*QueueInfo{"default": leaf}, applications: map[string]*ApplicationInfo{}}
+
+	tracker, err := newUserGroupTracker([]configs.Limit{{Users: []string{"bob"}, MaxApplications: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error building tracker: %v", err)
+	}
+	pi := &PartitionInfo{Root: root, applications: map[string]*ApplicationInfo{}, userGroupTracker: tracker}
+
+	first := &ApplicationInfo{ApplicationID: "app-1", QueueName: "root.default", User: "bob"}
+	if err := pi.AddNewApplication(first); err != nil {
+		t.Fatalf("expected the first application to be admitted, got: %v", err)
+	}
+
+	second := &ApplicationInfo{ApplicationID: "app-2", QueueName: "root.default", User: "bob"}
+	if err := pi.AddNewApplication(second); err == nil {
+		t.Fatal("expected the second application to be rejected for exceeding bob's maxapplications quota")
+	}
+	if _, ok := leaf.applications[second.ApplicationID]; ok {
+		t.Fatal("a rejected application must not be recorded on the queue")
+	}
+}
+
+// TestAddNewApplicationRejectsOverUserQuotaAcrossSiblingQueues verifies that a
+// limit scoped to a parent queue aggregates usage recorded in its child
+// queues, instead of only matching applications submitted to the parent
+// queue's exact path.
+func TestAddNewApplicationRejectsOverUserQuotaAcrossSiblingQueues(t *testing.T) {
+	team1 := &QueueInfo{Name: "root.dept.team1", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{}}
+	team2 := &QueueInfo{Name: "root.dept.team2", children: map[string]*QueueInfo{}, applications: map[string]*ApplicationInfo{}}
+	dept := &QueueInfo{Name: "root.dept", children: map[string]*QueueInfo{"team1": team1, "team2": team2}, applications: map[string]*ApplicationInfo{}}
+	root := &QueueInfo{Name: "root", children: map[string]*QueueInfo{"dept": dept}, applications: map[string]*ApplicationInfo{}}
+
+	tracker, err := newUserGroupTracker([]configs.Limit{{QueuePath: "root.dept", Users: []string{"bob"}, MaxApplications: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error building tracker: %v", err)
+	}
+	pi := &PartitionInfo{Root: root, applications: map[string]*ApplicationInfo{}, userGroupTracker: tracker}
+
+	first := &ApplicationInfo{ApplicationID: "app-1", QueueName: "root.dept.team1", User: "bob"}
+	if err := pi.AddNewApplication(first); err != nil {
+		t.Fatalf("expected the first application to be admitted, got: %v", err)
+	}
+
+	second := &ApplicationInfo{ApplicationID: "app-2", QueueName: "root.dept.team2", User: "bob"}
+	if err := pi.AddNewApplication(second); err == nil {
+		t.Fatal("expected the second application, in a sibling queue under the same limited parent, to be rejected")
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/common"
+	"github.com/apache/incubator-yunikorn-core/pkg/common/configs"
+	"github.com/apache/incubator-yunikorn-core/pkg/common/resources"
+)
+
+// Error codes returned in a QueueValidationIssue. These are machine readable so
+// callers of the /ws/v1/validate-conf endpoint can branch on the kind of problem
+// without parsing the message string.
+const (
+	IssueGuaranteedExceedsParent      = "GUARANTEED_EXCEEDS_PARENT"
+	IssueMaxLessThanGuaranteed        = "MAX_LESS_THAN_GUARANTEED"
+	IssueChildMaxExceedsParent        = "CHILD_MAX_EXCEEDS_PARENT"
+	IssueUnknownResourceType          = "UNKNOWN_RESOURCE_TYPE"
+	IssueInvalidACL                   = "INVALID_ACL"
+	IssueMaxApplicationsExceedsParent = "MAX_APPLICATIONS_EXCEEDS_PARENT"
+	IssueInvalidConfig                = "INVALID_CONFIG"
+)
+
+// QueueValidationIssue is a single problem found while validating a candidate
+// config, scoped to one queue.
+type QueueValidationIssue struct {
+	Path     string
+	Severity string
+	Code     string
+	Message  string
+}
+
+// ValidationReport is the result of validating a candidate scheduler config
+// without applying it to the live ClusterInfo.
+type ValidationReport struct {
+	Valid  bool
+	Issues []*QueueValidationIssue
+}
+
+// ValidateSchedulerConfig builds each partition with newPartitionInfo and runs
+// the queue resource and maxapplications checks of
+// checkResourceConfigurationsForQueue against a candidate config supplied as
+// raw yaml, without touching ClusterInfo or the global configs.ConfigContext.
+// The placement rule and user/group limit checks of newPartitionInfoInternal
+// are not run. It backs the dry-run "preview this config change" REST call.
+func ValidateSchedulerConfig(yamlBytes []byte, rmID string) (*ValidationReport, error) {
+	conf := &configs.SchedulerConfig{}
+	if err := yaml.Unmarshal(yamlBytes, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse candidate config: %v", err)
+	}
+
+	report := &ValidationReport{Valid: true}
+	for _, p := range conf.Partitions {
+		partitionName := common.GetNormalizedPartitionName(p.Name, rmID)
+		p.Name = partitionName
+		partition, err := newPartitionInfo(p, rmID, nil)
+		if err != nil {
+			report.Valid = false
+			report.Issues = append(report.Issues, &QueueValidationIssue{
+				Path:     partitionName,
+				Severity: "error",
+				Code:     classifyPartitionError(err),
+				Message:  err.Error(),
+			})
+			continue
+		}
+		issues := collectQueueConfigurationIssues(partition.Root, nil)
+		if len(issues) > 0 {
+			report.Valid = false
+			report.Issues = append(report.Issues, issues...)
+		}
+	}
+
+	return report, nil
+}
+
+// collectQueueConfigurationIssues mirrors checkResourceConfigurationsForQueue but,
+// instead of aborting on the first problem, walks the whole tree and reports every
+// violation it finds. checkResourceConfigurationsForQueue keeps its fail-fast
+// behaviour for the load path; this is only used for the dry-run report where
+// operators want the complete picture in one call.
+func collectQueueConfigurationIssues(cur *QueueInfo, parent *QueueInfo) []*QueueValidationIssue {
+	if parent == nil {
+		resolveMaxApplicationsForQueue(cur)
+	}
+	issues := make([]*QueueValidationIssue, 0)
+
+	if parent != nil && parent.MaxApplications > 0 && cur.MaxApplications > parent.MaxApplications {
+		issues = append(issues, &QueueValidationIssue{
+			Path:     cur.Name,
+			Severity: "error",
+			Code:     IssueMaxApplicationsExceedsParent,
+			Message:  fmt.Sprintf("queue %s has maxapplications (%d) set larger than parent's maxapplications (%d)", cur.Name, cur.MaxApplications, parent.MaxApplications),
+		})
+	}
+
+	if len(cur.children) > 0 {
+		for _, child := range cur.children {
+			issues = append(issues, collectQueueConfigurationIssues(child, cur)...)
+		}
+
+		sum := resourcesSumOfChildrenGuaranteed(cur)
+		if cur.guaranteedResource == nil {
+			cur.guaranteedResource = sum
+		} else if !resources.FitIn(cur.guaranteedResource, sum) {
+			issues = append(issues, &QueueValidationIssue{
+				Path:     cur.Name,
+				Severity: "error",
+				Code:     IssueGuaranteedExceedsParent,
+				Message:  fmt.Sprintf("queue %s has guaranteed-resources (%v) smaller than sum of children guaranteed resources (%v)", cur.Name, cur.guaranteedResource, sum),
+			})
+		}
+	} else if cur.guaranteedResource == nil {
+		cur.guaranteedResource = resources.NewResource()
+	}
+
+	if cur.maxResource != nil {
+		if parent != nil && parent.maxResource != nil && !resources.FitIn(parent.maxResource, cur.maxResource) {
+			issues = append(issues, &QueueValidationIssue{
+				Path:     cur.Name,
+				Severity: "error",
+				Code:     IssueChildMaxExceedsParent,
+				Message:  fmt.Sprintf("queue %s has max resources (%v) set larger than parent's max resources (%v)", cur.Name, cur.maxResource, parent.maxResource),
+			})
+		}
+		guaranteed := cur.guaranteedResource
+		if guaranteed == nil {
+			guaranteed = resources.NewResource()
+		}
+		if !resources.FitIn(cur.maxResource, guaranteed) {
+			issues = append(issues, &QueueValidationIssue{
+				Path:     cur.Name,
+				Severity: "error",
+				Code:     IssueMaxLessThanGuaranteed,
+				Message:  fmt.Sprintf("queue %s has max resources (%v) set smaller than guaranteed resources (%v)", cur.Name, cur.maxResource, guaranteed),
+			})
+		}
+	}
+
+	return issues
+}
+
+// resourcesSumOfChildrenGuaranteed adds up the guaranteed resource of every
+// direct child of cur, for collectQueueConfigurationIssues. It is the same sum
+// checkResourceConfigurationsForQueue computes inline on the real load path.
+func resourcesSumOfChildrenGuaranteed(cur *QueueInfo) *resources.Resource {
+	sum := resources.NewResource()
+	for _, child := range cur.children {
+		sum.AddTo(child.guaranteedResource)
+	}
+	return sum
+}
+
+// classifyPartitionError maps an error returned by newPartitionInfo to a
+// machine-readable issue code. newPartitionInfo itself returns a plain error
+// for every kind of construction failure (bad ACL, unknown resource type, a
+// malformed queue path, ...), so the message is inspected for the markers
+// those failures are known to use rather than leaving every one of them
+// mislabeled as a single catch-all code.
+func classifyPartitionError(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "acl"):
+		return IssueInvalidACL
+	case strings.Contains(msg, "resource type") || strings.Contains(msg, "unknown resource"):
+		return IssueUnknownResourceType
+	default:
+		return IssueInvalidConfig
+	}
+}
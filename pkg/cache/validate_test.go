@@ -0,0 +1,45 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cache
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassifyPartitionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"acl parse failure", errors.New("failed to parse ACL \"nobody\" for queue root.default"), IssueInvalidACL},
+		{"unknown resource", errors.New("unknown resource type gpu-v2 in queue root.default"), IssueUnknownResourceType},
+		{"resource type wording", errors.New("invalid resource type encountered"), IssueUnknownResourceType},
+		{"anything else", errors.New("queue root.default.child is not a known queue"), IssueInvalidConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyPartitionError(tt.err); got != tt.want {
+				t.Errorf("classifyPartitionError(%q) = %s, want %s", tt.err.Error(), got, tt.want)
+			}
+		})
+	}
+}
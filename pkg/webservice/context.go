@@ -0,0 +1,42 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import "github.com/apache/incubator-yunikorn-core/pkg/cache"
+
+// clusterInfo is the live cluster state the webservice handlers read from. It
+// is wired up by the scheduler on startup, the same way the REST handlers for
+// partitions, queues and applications already look it up.
+var clusterInfo *cache.ClusterInfo
+
+// SetClusterInfo wires the webservice package to the running scheduler's
+// cluster state. Must be called once during startup before the REST server
+// starts serving requests.
+func SetClusterInfo(info *cache.ClusterInfo) {
+	clusterInfo = info
+}
+
+// getPartitionInfo looks up a partition by its normalized name, or nil if it
+// is not known to the running cluster.
+func getPartitionInfo(partitionName string) *cache.PartitionInfo {
+	if clusterInfo == nil {
+		return nil
+	}
+	return clusterInfo.GetPartition(partitionName)
+}
@@ -0,0 +1,28 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package dao
+
+// QueueApplicationsDAOInfo reports a queue's running application count against
+// its configured ceiling, for the per-queue REST views under
+// /ws/v1/partition/:partition/queue/:queue.
+type QueueApplicationsDAOInfo struct {
+	QueuePath           string `json:"queuePath"`
+	MaxApplications     int    `json:"maxApplications"`
+	RunningApplications int    `json:"runningApplications"`
+}
@@ -0,0 +1,52 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/webservice/dao"
+)
+
+// getPlacementRules handles GET /ws/v1/partition/:partition/placementrules. It
+// returns the active, validated placement rule chain for the partition in the
+// order rules are evaluated, the implicit recovery rule included.
+func getPlacementRules(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	partitionName := vars["partition"]
+
+	partition := getPartitionInfo(partitionName)
+	if partition == nil {
+		http.Error(w, "partition "+partitionName+" not found", http.StatusNotFound)
+		return
+	}
+
+	result := &dao.PlacementRulesDAOInfo{
+		Partition: partitionName,
+		Rules:     partition.PlacementRuleNames(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/cache"
+	"github.com/apache/incubator-yunikorn-core/pkg/webservice/dao"
+)
+
+// getQueueApplications handles GET
+// /ws/v1/partition/:partition/queue/:queue/applications. The queue path
+// parameter is the full dotted path (root.parent.child).
+func getQueueApplications(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	partition := getPartitionInfo(vars["partition"])
+	if partition == nil {
+		http.Error(w, "partition "+vars["partition"]+" not found", http.StatusNotFound)
+		return
+	}
+
+	queue := cache.FindQueueByPath(partition.Root, vars["queue"])
+	if queue == nil {
+		http.Error(w, "queue "+vars["queue"]+" not found", http.StatusNotFound)
+		return
+	}
+
+	result := &dao.QueueApplicationsDAOInfo{
+		QueuePath:           vars["queue"],
+		MaxApplications:     queue.MaxApplications,
+		RunningApplications: queue.RunningApplications(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
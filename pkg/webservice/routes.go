@@ -0,0 +1,33 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import "github.com/gorilla/mux"
+
+// RegisterRoutes wires every handler in this package onto router, alongside
+// the existing partition/queue/application routes. It is called once by
+// StartWebApp during webservice startup.
+func RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/ws/v1/validate-conf", validateConf).Methods("POST")
+	router.HandleFunc("/ws/v1/partition/{partition}/queue/{queue}/applications", getQueueApplications).Methods("GET")
+	router.HandleFunc("/ws/v1/partition/{partition}/placementrules", getPlacementRules).Methods("GET")
+	router.HandleFunc("/ws/v1/partition/{partition}/usage/user/{name}", getUserUsage).Methods("GET")
+	router.HandleFunc("/ws/v1/partition/{partition}/usage/group/{name}", getGroupUsage).Methods("GET")
+	router.HandleFunc("/ws/v1/partition/{partition}/usage", getPartitionUsage).Methods("GET")
+}
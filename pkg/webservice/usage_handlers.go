@@ -0,0 +1,101 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/cache"
+	"github.com/apache/incubator-yunikorn-core/pkg/webservice/dao"
+)
+
+// getUserUsage handles GET /ws/v1/partition/:partition/usage/user/:name.
+func getUserUsage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	partition := getPartitionInfo(vars["partition"])
+	if partition == nil {
+		http.Error(w, "partition "+vars["partition"]+" not found", http.StatusNotFound)
+		return
+	}
+	writeUsage(w, vars["name"], partition.GetUserUsage(vars["name"]))
+}
+
+// getGroupUsage handles GET /ws/v1/partition/:partition/usage/group/:name.
+func getGroupUsage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	partition := getPartitionInfo(vars["partition"])
+	if partition == nil {
+		http.Error(w, "partition "+vars["partition"]+" not found", http.StatusNotFound)
+		return
+	}
+	writeUsage(w, vars["name"], partition.GetGroupUsage(vars["name"]))
+}
+
+// getPartitionUsage handles GET /ws/v1/partition/:partition/usage. It returns
+// every user's and group's recorded usage in the partition in one call, so
+// operators can see quota pressure across the whole partition without a
+// separate round trip per user or group.
+func getPartitionUsage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	partition := getPartitionInfo(vars["partition"])
+	if partition == nil {
+		http.Error(w, "partition "+vars["partition"]+" not found", http.StatusNotFound)
+		return
+	}
+
+	users, groups := partition.AggregateUsage()
+	result := &dao.PartitionAggregateUsageDAOInfo{
+		Users:  make([]*dao.UserResourceUsageDAOInfo, 0, len(users)),
+		Groups: make([]*dao.UserResourceUsageDAOInfo, 0, len(groups)),
+	}
+	for _, usage := range users {
+		result.Users = append(result.Users, toUserResourceUsageDAO(usage.Name, usage))
+	}
+	for _, usage := range groups {
+		result.Groups = append(result.Groups, toUserResourceUsageDAO(usage.Name, usage))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeUsage(w http.ResponseWriter, name string, usage *cache.UserResourceUsage) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toUserResourceUsageDAO(name, usage)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func toUserResourceUsageDAO(name string, usage *cache.UserResourceUsage) *dao.UserResourceUsageDAOInfo {
+	result := &dao.UserResourceUsageDAOInfo{
+		Name:        name,
+		Queues:      make(map[string]string),
+		Total:       usage.Total.String(),
+		RunningApps: usage.RunningApps,
+	}
+	for queuePath, used := range usage.PerQueue {
+		result.Queues[queuePath] = used.String()
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+/*
+ Licensed to the Apache Software Foundation (ASF) under one
+ or more contributor license agreements.  See the NOTICE file
+ distributed with this work for additional information
+ regarding copyright ownership.  The ASF licenses this file
+ to you under the Apache License, Version 2.0 (the
+ "License"); you may not use this file except in compliance
+ with the License.  You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package webservice
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/apache/incubator-yunikorn-core/pkg/cache"
+)
+
+// StartWebApp wires the webservice package to the running scheduler's cluster
+// state and starts serving the REST API on addr. It is the scheduler's
+// startup entry point into this package: call it once, after a partition has
+// been registered via cache.SetClusterInfoFromConfigFile, and before
+// accepting RM traffic.
+func StartWebApp(info *cache.ClusterInfo, addr string) error {
+	SetClusterInfo(info)
+
+	router := mux.NewRouter()
+	RegisterRoutes(router)
+
+	return http.ListenAndServe(addr, router)
+}